@@ -0,0 +1,332 @@
+// Package v1alpha1 contains the API Schema definitions for the pool-policy
+// v1alpha1 API group.
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	nodeutil "github.com/llm-d-incubation/llm-d-fast-model-actuation/pkg/utils/node"
+)
+
+// GroupVersion is the API group and version used to register these types.
+var GroupVersion = schema.GroupVersion{Group: "policy.llm-d.ai", Version: "v1alpha1"}
+
+// SchemeBuilder collects functions that add types to a scheme.
+var SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+// AddToScheme adds the types in this group-version to the given scheme.
+var AddToScheme = SchemeBuilder.AddToScheme
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&LauncherPoolPolicy{},
+		&LauncherPoolPolicyList{},
+		&LauncherConfig{},
+		&LauncherConfigList{},
+	)
+	metav1.AddToGroupVersion(scheme, GroupVersion)
+	return nil
+}
+
+// LauncherPoolPolicy describes how many launcher pods of which LauncherConfig
+// should be kept warm on which nodes.
+type LauncherPoolPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LauncherPoolPolicySpec   `json:"spec,omitempty"`
+	Status LauncherPoolPolicyStatus `json:"status,omitempty"`
+}
+
+// LauncherPoolPolicyList is a list of LauncherPoolPolicy.
+type LauncherPoolPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LauncherPoolPolicy `json:"items"`
+}
+
+// LauncherPoolPolicySpec is the desired state of a LauncherPoolPolicy.
+type LauncherPoolPolicySpec struct {
+	// LauncherPoolForNodeType pairs a node selector with the launcher counts
+	// that should be maintained on every node it matches.
+	LauncherPoolForNodeType []NodePoolSpec `json:"launcherPoolForNodeType,omitempty"`
+
+	// CreationRate bounds how fast this policy may create or delete
+	// launcher pods on any single node. If unset, the controller applies
+	// its own default rate.
+	CreationRate *CreationRate `json:"creationRate,omitempty"`
+
+	// MaxLaunchersPerNode caps the total launcher pods (summed across every
+	// CountForLauncher template) this policy may place on one node. A node
+	// can override this with the MaxLaunchersPerNodeAnnotationName
+	// annotation. Unset means no cap.
+	MaxLaunchersPerNode *int32 `json:"maxLaunchersPerNode,omitempty"`
+}
+
+// CreationRate configures a token-bucket rate limit.
+type CreationRate struct {
+	// QPS is the steady-state number of operations allowed per second.
+	QPS float64 `json:"qps,omitempty"`
+	// Burst is the maximum number of operations allowed in a single burst.
+	Burst int `json:"burst,omitempty"`
+}
+
+// NodePoolSpec selects a set of nodes and describes the launcher pods that
+// should be kept warm on each of them.
+type NodePoolSpec struct {
+	EnhancedNodeSelector nodeutil.EnhancedNodeSelector `json:"enhancedNodeSelector,omitempty"`
+	CountForLauncher     []CountForLauncher            `json:"countForLauncher,omitempty"`
+}
+
+// CountForLauncher specifies how many idle pods of a given LauncherConfig
+// should be kept warm per matching node, and how to resolve that count.
+type CountForLauncher struct {
+	LauncherConfigName string `json:"launcherConfigName"`
+
+	// Strategy selects exactly one of Fixed, TopologySpread, or Weighted to
+	// determine the desired count per matching node.
+	Strategy LauncherCountStrategy `json:"strategy,omitempty"`
+
+	// Priority ranks this template against every other template matching
+	// the same node when the node's launcher budget (Spec.MaxLaunchersPerNode
+	// or a node's override annotation) is exceeded: idle launchers of
+	// lower-priority templates are deleted before higher-priority ones are
+	// created. Higher values win; among templates with equal Priority, the
+	// one listed first is trimmed first.
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// LauncherCountStrategy selects one way to resolve a per-node desired count.
+type LauncherCountStrategy struct {
+	Fixed          *FixedStrategy          `json:"fixed,omitempty"`
+	TopologySpread *TopologySpreadStrategy `json:"topologySpread,omitempty"`
+	Weighted       *WeightedStrategy       `json:"weighted,omitempty"`
+}
+
+// FixedStrategy keeps the same count warm on every matching node.
+type FixedStrategy struct {
+	Count int32 `json:"count"`
+}
+
+// TopologySpreadStrategy spreads a total count evenly across matching
+// nodes, first by topology domain and then by node within each domain.
+type TopologySpreadStrategy struct {
+	// TopologyKey is the node label that groups matching nodes into
+	// domains, e.g. topology.kubernetes.io/zone.
+	TopologyKey string `json:"topologyKey"`
+	// Target is the total launcher pod count to spread across every
+	// matching node.
+	Target int32 `json:"target"`
+}
+
+// WeightedStrategy gives this template a share of a node's launcher budget
+// relative to every other Weighted template matching the same node:
+// desired = nodeBudget * Weight / sum(Weight of co-located Weighted templates).
+type WeightedStrategy struct {
+	Weight int32 `json:"weight"`
+}
+
+// LauncherPoolPolicyStatus is the observed state of a LauncherPoolPolicy.
+type LauncherPoolPolicyStatus struct {
+	ObservedGeneration int32    `json:"observedGeneration,omitempty"`
+	Errors             []string `json:"errors,omitempty"`
+
+	// ThrottledCreates counts create attempts delayed by rate limiting
+	// since this policy was created.
+	ThrottledCreates int64 `json:"throttledCreates,omitempty"`
+	// ThrottledDeletes counts delete attempts delayed by rate limiting
+	// since this policy was created.
+	ThrottledDeletes int64 `json:"throttledDeletes,omitempty"`
+
+	// NodeStatuses reports the last observed/desired launcher counts per
+	// node and LauncherConfig, so rollout progress is visible from
+	// `kubectl get launcherpoolpolicy -o yaml` without a Prometheus query.
+	NodeStatuses []NodeStatus `json:"nodeStatuses,omitempty"`
+}
+
+// NodeStatus reports the launcher pool state for one (node, LauncherConfig) pair.
+type NodeStatus struct {
+	Node           string `json:"node"`
+	LauncherConfig string `json:"launcherConfig"`
+	Desired        int32  `json:"desired"`
+	Observed       int32  `json:"observed"`
+
+	// LastTransitionTime is updated whenever Desired or Observed changes.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// LauncherConfig is a reusable template for launcher pods.
+type LauncherConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LauncherConfigSpec `json:"spec,omitempty"`
+}
+
+// LauncherConfigList is a list of LauncherConfig.
+type LauncherConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LauncherConfig `json:"items"`
+}
+
+// LauncherConfigSpec is the desired state of a LauncherConfig.
+type LauncherConfigSpec struct {
+	// PodTemplate is copied onto every launcher pod created from this config.
+	PodTemplate corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
+
+	// Overlay carries environment-specific additions that a Helm chart
+	// deploying this LauncherConfig wants applied on top of PodTemplate,
+	// without requiring a rebuild of the LauncherConfig CR itself.
+	Overlay *Overlay `json:"overlay,omitempty"`
+}
+
+// Overlay is merged onto the materialized launcher Pod after PodTemplate is copied.
+type Overlay struct {
+	// ExtraEnv is appended to every container's env. An entry whose Name
+	// matches an existing env var overrides that var's value.
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+
+	// ExtraVolumes is appended to the pod's volumes.
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts is appended to every container's volume mounts.
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// ImagePullSecrets is appended to the pod's image pull secrets.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// NodeSelectorOverlay is merged into the pod's node selector, overriding
+	// any key also set by PodTemplate.
+	NodeSelectorOverlay map[string]string `json:"nodeSelectorOverlay,omitempty"`
+
+	// ImageFromEnv names an environment variable (e.g. "SIDECAR_IMAGE") read
+	// on the controller process; when set, its value overrides the image of
+	// ImageFromEnvContainer. This mirrors the existing LAUNCHER_IMAGE /
+	// CONTAINER_IMG_REG override but is scoped to a single container so a
+	// sidecar's image can be parameterized independently of the launcher's.
+	ImageFromEnv string `json:"imageFromEnv,omitempty"`
+
+	// ImageFromEnvContainer names the container ImageFromEnv applies to. If
+	// empty, ImageFromEnv applies to every container.
+	ImageFromEnvContainer string `json:"imageFromEnvContainer,omitempty"`
+}
+
+// DeepCopy returns a deep copy of spec, including the CreationRate and
+// MaxLaunchersPerNode pointers and the nested CountForLauncher slices, so
+// callers holding an informer cache's copy can't have it mutated out from
+// under them through a shallow struct copy.
+func (in *LauncherPoolPolicySpec) DeepCopy() *LauncherPoolPolicySpec {
+	out := *in
+	if in.LauncherPoolForNodeType != nil {
+		out.LauncherPoolForNodeType = make([]NodePoolSpec, len(in.LauncherPoolForNodeType))
+		for i := range in.LauncherPoolForNodeType {
+			out.LauncherPoolForNodeType[i] = *in.LauncherPoolForNodeType[i].DeepCopy()
+		}
+	}
+	if in.CreationRate != nil {
+		cr := *in.CreationRate
+		out.CreationRate = &cr
+	}
+	if in.MaxLaunchersPerNode != nil {
+		m := *in.MaxLaunchersPerNode
+		out.MaxLaunchersPerNode = &m
+	}
+	return &out
+}
+
+// DeepCopy returns a deep copy of np, including its CountForLauncher slice
+// and each entry's Strategy pointers.
+func (in *NodePoolSpec) DeepCopy() *NodePoolSpec {
+	out := *in
+	if in.CountForLauncher != nil {
+		out.CountForLauncher = append([]CountForLauncher(nil), in.CountForLauncher...)
+		for i := range out.CountForLauncher {
+			s := &out.CountForLauncher[i].Strategy
+			if s.Fixed != nil {
+				f := *s.Fixed
+				s.Fixed = &f
+			}
+			if s.TopologySpread != nil {
+				ts := *s.TopologySpread
+				s.TopologySpread = &ts
+			}
+			if s.Weighted != nil {
+				w := *s.Weighted
+				s.Weighted = &w
+			}
+		}
+	}
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *LauncherPoolPolicy) DeepCopyObject() runtime.Object {
+	out := *in
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	out.Spec = *in.Spec.DeepCopy()
+	out.Status.Errors = append([]string(nil), in.Status.Errors...)
+	out.Status.NodeStatuses = append([]NodeStatus(nil), in.Status.NodeStatuses...)
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *LauncherPoolPolicyList) DeepCopyObject() runtime.Object {
+	out := *in
+	out.Items = make([]LauncherPoolPolicy, len(in.Items))
+	for i := range in.Items {
+		out.Items[i] = *in.Items[i].DeepCopyObject().(*LauncherPoolPolicy)
+	}
+	return &out
+}
+
+// DeepCopy returns a deep copy of the overlay, including its slice and map fields.
+func (in *Overlay) DeepCopy() *Overlay {
+	out := *in
+	out.ExtraEnv = append([]corev1.EnvVar(nil), in.ExtraEnv...)
+	out.ExtraVolumes = append([]corev1.Volume(nil), in.ExtraVolumes...)
+	out.ExtraVolumeMounts = append([]corev1.VolumeMount(nil), in.ExtraVolumeMounts...)
+	out.ImagePullSecrets = append([]corev1.LocalObjectReference(nil), in.ImagePullSecrets...)
+	if in.NodeSelectorOverlay != nil {
+		out.NodeSelectorOverlay = make(map[string]string, len(in.NodeSelectorOverlay))
+		for k, v := range in.NodeSelectorOverlay {
+			out.NodeSelectorOverlay[k] = v
+		}
+	}
+	return &out
+}
+
+// DeepCopy returns a deep copy of spec, including the PodTemplate and the
+// Overlay pointer, so the informer cache's copy of a LauncherConfig can't be
+// mutated out from under it by a caller building a pod from the template.
+func (in *LauncherConfigSpec) DeepCopy() *LauncherConfigSpec {
+	out := *in
+	out.PodTemplate = *in.PodTemplate.DeepCopy()
+	if in.Overlay != nil {
+		out.Overlay = in.Overlay.DeepCopy()
+	}
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *LauncherConfig) DeepCopyObject() runtime.Object {
+	out := *in
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	out.Spec = *in.Spec.DeepCopy()
+	return &out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *LauncherConfigList) DeepCopyObject() runtime.Object {
+	out := *in
+	out.Items = make([]LauncherConfig, len(in.Items))
+	for i := range in.Items {
+		out.Items[i] = *in.Items[i].DeepCopyObject().(*LauncherConfig)
+	}
+	return &out
+}
@@ -6,23 +6,37 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	pkgapi "github.com/llm-d-incubation/llm-d-fast-model-actuation/pkg/api"
 	poolpolicy "github.com/llm-d-incubation/llm-d-fast-model-actuation/pkg/controller/pool-policy"
 )
 
 func main() {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	overrides := &clientcmd.ConfigOverrides{}
+	var metricsBindAddress string
+	var watchNamespaces []string
 
 	klog.InitFlags(flag.CommandLine)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	AddFlags(*pflag.CommandLine, loadingRules, overrides)
+	pflag.StringVar(&metricsBindAddress, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to; set to \"0\" to disable")
+	pflag.StringArrayVar(&watchNamespaces, "watch-namespace", nil, "An additional namespace to restrict the manager's cache to; may be repeated. Combined with --namespace")
 	pflag.Parse()
 
-	// Optional: a namespace can be provided to limit the manager's watch scope.
+	// --namespace and --watch-namespace together restrict the manager's
+	// cache to a set of namespaces (controller-runtime v0.22 doesn't support
+	// Options.Namespace, but does support Cache.DefaultNamespaces). Leaving
+	// both unset watches every namespace, as before.
+	namespaces := dedupNamespaces(overrides.Context.Namespace, watchNamespaces)
 
 	// Build kubeconfig from the environment / kubeconfig flags
 	restCfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
@@ -42,10 +56,28 @@ func main() {
 		logger.V(1).Info("Flag", "name", f.Name, "value", f.Value.String())
 	})
 
-	// Create manager (controller-runtime v0.22 does not support restricting
-	// namespaces via Options.Namespace in this version). The optional
-	// --namespace flag is informational for this binary.
-	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{})
+	cacheOpts := cache.Options{
+		// Annotation selectors aren't supported by ByObject, so the Pod
+		// cache is scoped to LauncherBasedLabelName instead, which
+		// createLauncherPod sets alongside the annotation. This drops Pod
+		// cache memory dramatically in large clusters.
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Pod{}: {
+				Label: labels.SelectorFromSet(labels.Set{pkgapi.LauncherBasedLabelName: "true"}),
+			},
+		},
+	}
+	if len(namespaces) > 0 {
+		cacheOpts.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+		for _, ns := range namespaces {
+			cacheOpts.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+
+	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
+		Metrics: metricsserver.Options{BindAddress: metricsBindAddress},
+		Cache:   cacheOpts,
+	})
 	if err != nil {
 		klog.Fatal(err)
 	}
@@ -54,10 +86,10 @@ func main() {
 		klog.Fatal(err)
 	}
 
-	if overrides.Context.Namespace == "" {
-		klog.Info("starting poolpolicy controller", "watchNamespace", "<all>")
+	if len(namespaces) == 0 {
+		klog.Info("starting poolpolicy controller", "watchNamespaces", "<all>")
 	} else {
-		klog.Info("starting poolpolicy controller", "watchNamespace", overrides.Context.Namespace)
+		klog.Info("starting poolpolicy controller", "watchNamespaces", namespaces)
 	}
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		klog.Fatalf("manager exited: %v", err)
@@ -67,6 +99,25 @@ func main() {
 	time.Sleep(100 * time.Millisecond)
 }
 
+// dedupNamespaces combines primary (the --namespace flag, may be empty) with
+// extra (repeated --watch-namespace values), dropping empties and duplicates.
+func dedupNamespaces(primary string, extra []string) []string {
+	seen := make(map[string]bool, len(extra)+1)
+	var namespaces []string
+	add := func(ns string) {
+		if ns == "" || seen[ns] {
+			return
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	add(primary)
+	for _, ns := range extra {
+		add(ns)
+	}
+	return namespaces
+}
+
 func AddFlags(flags pflag.FlagSet, loadingRules *clientcmd.ClientConfigLoadingRules, overrides *clientcmd.ConfigOverrides) {
 	flags.StringVar(&loadingRules.ExplicitPath, "kubeconfig", loadingRules.ExplicitPath, "Path to the kubeconfig file to use")
 	flags.StringVar(&overrides.CurrentContext, "context", overrides.CurrentContext, "The name of the kubeconfig context to use")
@@ -0,0 +1,124 @@
+// Command trigger-uninstall sets the UninstallingAnnotationName annotation
+// on a named LauncherPoolPolicy and blocks until its CleanupFinalizerName
+// finalizer is gone. It is meant to be run from a Job during chart
+// uninstall, so launcher pods are drained deterministically instead of
+// racing owner-reference GC against the controller Deployment also being
+// removed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1alpha1 "github.com/llm-d-incubation/llm-d-fast-model-actuation/api/v1alpha1"
+	pkgapi "github.com/llm-d-incubation/llm-d-fast-model-actuation/pkg/api"
+)
+
+func main() {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+
+	var policyName string
+	var pollInterval, timeout time.Duration
+
+	klog.InitFlags(flag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.StringVar(&loadingRules.ExplicitPath, "kubeconfig", loadingRules.ExplicitPath, "Path to the kubeconfig file to use")
+	pflag.StringVarP(&overrides.Context.Namespace, "namespace", "n", overrides.Context.Namespace, "The Namespace the LauncherPoolPolicy lives in (NOT optional)")
+	pflag.StringVar(&policyName, "policy", "", "The name of the LauncherPoolPolicy to uninstall (NOT optional)")
+	pflag.DurationVar(&pollInterval, "poll-interval", 2*time.Second, "How often to check whether the cleanup finalizer has been removed")
+	pflag.DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait for the cleanup finalizer to be removed before giving up")
+	pflag.Parse()
+
+	if overrides.Context.Namespace == "" || policyName == "" {
+		klog.Fatal("--namespace and --policy are required")
+	}
+
+	restCfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		klog.Fatal(err)
+	}
+	restCfg.UserAgent = "trigger-uninstall"
+
+	cl, err := ctrlclient.New(restCfg, ctrlclient.Options{})
+	if err != nil {
+		klog.Fatal(err)
+	}
+	if err := v1alpha1.AddToScheme(cl.Scheme()); err != nil {
+		klog.Fatal(err)
+	}
+
+	ctx := context.Background()
+	namespacedName := apitypes.NamespacedName{Namespace: overrides.Context.Namespace, Name: policyName}
+
+	if err := markUninstalling(ctx, cl, namespacedName); err != nil {
+		klog.Fatal(err)
+	}
+	klog.Infof("marked policy %s as uninstalling", namespacedName)
+
+	if err := waitForFinalizerRemoved(ctx, cl, namespacedName, pollInterval, timeout); err != nil {
+		klog.Fatal(err)
+	}
+	klog.Infof("cleanup finalizer removed from %s, uninstall complete", namespacedName)
+}
+
+func markUninstalling(ctx context.Context, cl ctrlclient.Client, name apitypes.NamespacedName) error {
+	var policy v1alpha1.LauncherPoolPolicy
+	if err := cl.Get(ctx, name, &policy); err != nil {
+		return fmt.Errorf("getting LauncherPoolPolicy %s: %w", name, err)
+	}
+	if policy.Annotations == nil {
+		policy.Annotations = map[string]string{}
+	}
+	if policy.Annotations[pkgapi.UninstallingAnnotationName] == "true" {
+		return nil
+	}
+	policy.Annotations[pkgapi.UninstallingAnnotationName] = "true"
+	if err := cl.Update(ctx, &policy); err != nil {
+		return fmt.Errorf("annotating LauncherPoolPolicy %s: %w", name, err)
+	}
+	return nil
+}
+
+func waitForFinalizerRemoved(ctx context.Context, cl ctrlclient.Client, name apitypes.NamespacedName, pollInterval, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		var policy v1alpha1.LauncherPoolPolicy
+		err := cl.Get(ctx, name, &policy)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("getting LauncherPoolPolicy %s: %w", name, err)
+		}
+		if !hasFinalizer(policy.Finalizers, pkgapi.CleanupFinalizerName) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for cleanup finalizer to be removed from %s", name)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func hasFinalizer(finalizers []string, name string) bool {
+	for _, f := range finalizers {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
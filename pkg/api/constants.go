@@ -0,0 +1,37 @@
+// Package api holds the well-known annotation and label names shared between
+// the pool-policy controller and the objects it creates and watches.
+package api
+
+const (
+	// PolicyNameAnnotationName records the LauncherPoolPolicy that owns a launcher pod.
+	PolicyNameAnnotationName = "policy.llm-d.ai/pool-policy"
+
+	// LauncherConfigAnnotationName records the LauncherConfig a launcher pod was created from.
+	LauncherConfigAnnotationName = "policy.llm-d.ai/launcher-config"
+
+	// IdleLauncherAnnotationName marks a launcher pod as idle (not yet bound to a model).
+	IdleLauncherAnnotationName = "policy.llm-d.ai/idle-launcher"
+
+	// LauncherBasedAnnotationName marks any pod created by this controller, idle or not.
+	LauncherBasedAnnotationName = "policy.llm-d.ai/launcher-based"
+
+	// LauncherBasedLabelName mirrors LauncherBasedAnnotationName as a label.
+	// controller-runtime's cache ByObject selectors can't match on
+	// annotations, so the manager's Pod cache is scoped using this label
+	// instead, dropping full-namespace Pod caching in large clusters.
+	LauncherBasedLabelName = "llm-d.ai/launcher-based"
+
+	// UninstallingAnnotationName, when set to "true" on a LauncherPoolPolicy,
+	// tells the controller to drain all idle launchers for that policy and
+	// then remove CleanupFinalizerName, independent of owner-reference GC.
+	UninstallingAnnotationName = "policy.llm-d.ai/uninstalling"
+)
+
+// MaxLaunchersPerNodeAnnotationName on a Node overrides
+// LauncherPoolPolicySpec.MaxLaunchersPerNode for that node.
+const MaxLaunchersPerNodeAnnotationName = "policy.llm-d.ai/max-launchers-per-node"
+
+// CleanupFinalizerName blocks deletion of a LauncherPoolPolicy until its
+// launcher pods have been drained, so chart uninstall can tear down
+// deterministically instead of racing the controller's own removal.
+const CleanupFinalizerName = "pool-policy.llm-d.ai/cleanup"
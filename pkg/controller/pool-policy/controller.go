@@ -5,14 +5,24 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +32,7 @@ import (
 
 	v1alpha1 "github.com/llm-d-incubation/llm-d-fast-model-actuation/api/v1alpha1"
 	pkgapi "github.com/llm-d-incubation/llm-d-fast-model-actuation/pkg/api"
+	poolmetrics "github.com/llm-d-incubation/llm-d-fast-model-actuation/pkg/metrics"
 )
 
 // Note: LauncherPoolPolicy is namespaced. The controller will read
@@ -31,13 +42,98 @@ type Reconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Logger klog.Logger
+
+	// nodeLimiters holds a *rate.Limiter per node name, keyed by node.Name,
+	// so a policy that suddenly wants many launchers doesn't hammer the API
+	// server or the kubelet image puller on any single node.
+	nodeLimiters sync.Map
+
+	// globalLimiter caps aggregate create/delete calls across every node so
+	// a single policy spanning many nodes cannot monopolize the client.
+	globalLimiter     *rate.Limiter
+	globalLimiterOnce sync.Once
+
+	// SelectForDeletion picks which n of the given idle launcher pods to
+	// delete first when a node is over its desired count. Nil defaults to
+	// newestFirstSelectForDeletion. Exposed for tests and for callers that
+	// want a different preemption order (e.g. oldest-first, or one aware of
+	// per-pod cost).
+	SelectForDeletion func(pods []*corev1.Pod, n int) []*corev1.Pod
+}
+
+const (
+	// defaultNodeRateQPS/defaultNodeRateBurst apply when a LauncherPoolPolicy
+	// doesn't set Spec.CreationRate.
+	defaultNodeRateQPS   = 2.0
+	defaultNodeRateBurst = 5
+
+	// defaultGlobalRateQPS/defaultGlobalRateBurst bound the combined
+	// create/delete rate across all nodes touched by this controller.
+	defaultGlobalRateQPS   = 20.0
+	defaultGlobalRateBurst = 50
+)
+
+// nodeLimiter returns the rate limiter for a node, creating it on first use.
+// If the limiter already exists but qps/burst no longer match what's
+// requested (e.g. the policy's Spec.CreationRate changed), it is updated in
+// place so a later change in configuration actually takes effect instead of
+// being silently stuck with whatever was in force on first use.
+func (r *Reconciler) nodeLimiter(nodeName string, qps float64, burst int) *rate.Limiter {
+	if v, ok := r.nodeLimiters.Load(nodeName); ok {
+		lim := v.(*rate.Limiter)
+		if lim.Limit() != rate.Limit(qps) {
+			lim.SetLimit(rate.Limit(qps))
+		}
+		if lim.Burst() != burst {
+			lim.SetBurst(burst)
+		}
+		return lim
+	}
+	lim := rate.NewLimiter(rate.Limit(qps), burst)
+	actual, _ := r.nodeLimiters.LoadOrStore(nodeName, lim)
+	return actual.(*rate.Limiter)
+}
+
+// globalRateLimiter returns the Reconciler-wide limiter, creating it on first use.
+func (r *Reconciler) globalRateLimiter() *rate.Limiter {
+	r.globalLimiterOnce.Do(func() {
+		r.globalLimiter = rate.NewLimiter(defaultGlobalRateQPS, defaultGlobalRateBurst)
+	})
+	return r.globalLimiter
+}
+
+// reserveToken takes one token from both the per-node and global limiters
+// for a create or delete attempt on nodeName. If either has no token
+// available right now, any reservation already taken is cancelled and ok is
+// false with the delay the caller should wait before retrying.
+func (r *Reconciler) reserveToken(nodeName string, qps float64, burst int) (ok bool, delay time.Duration) {
+	nodeRes := r.nodeLimiter(nodeName, qps, burst).Reserve()
+	if d := nodeRes.Delay(); d > 0 {
+		nodeRes.Cancel()
+		return false, d
+	}
+
+	globalRes := r.globalRateLimiter().Reserve()
+	if d := globalRes.Delay(); d > 0 {
+		nodeRes.Cancel()
+		globalRes.Cancel()
+		return false, d
+	}
+	return true, 0
 }
 
 // Reconcile ensures desired counts of launcher pods per node and per launchConfig.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := klog.FromContext(ctx).WithName("poolpolicy-reconciler")
+	start := time.Now()
+	defer func() {
+		poolmetrics.ReconcileDuration.WithLabelValues(req.Name).Observe(time.Since(start).Seconds())
+	}()
+
 	var policy v1alpha1.LauncherPoolPolicy
 	var reconcileErrors []string
+	var throttledCreates, throttledDeletes int64
+	var requeueAfter time.Duration
 	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.V(4).Info("PoolPolicy not found (deleted)", "name", req.NamespacedName)
@@ -49,6 +145,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	logger.V(2).Info("Start reconcile", "policy", policy.Name)
 
+	// Uninstalling is driven either by the policy being deleted (finalizer
+	// present) or by an operator setting UninstallingAnnotationName ahead of
+	// deletion, e.g. from a chart-uninstall Job via trigger-uninstall.
+	uninstalling := policy.DeletionTimestamp != nil || policy.Annotations[pkgapi.UninstallingAnnotationName] == "true"
+
+	// Add our cleanup finalizer on first reconcile so we always get a chance
+	// to drain launchers before the policy object actually disappears,
+	// independent of owner-reference GC racing the controller's own removal.
+	// Gated on !uninstalling: once the operator has asked to uninstall but
+	// the object isn't deleted yet, adding the finalizer here only for the
+	// drain loop below to remove it again would churn the object's
+	// ResourceVersion and re-trigger this Reconcile forever.
+	if !uninstalling && controllerutil.AddFinalizer(&policy, pkgapi.CleanupFinalizerName) {
+		if err := r.Update(ctx, &policy); err != nil {
+			logger.Error(err, "failed to add cleanup finalizer", "name", policy.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
 	// List all nodes and group those that match each NodePoolSpec
 	var nodes corev1.NodeList
 	if err := r.List(ctx, &nodes); err != nil {
@@ -56,6 +171,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 
+	oldNodeStatusByKey := make(map[string]v1alpha1.NodeStatus, len(policy.Status.NodeStatuses))
+	for _, ns := range policy.Status.NodeStatuses {
+		oldNodeStatusByKey[ns.Node+"/"+ns.LauncherConfig] = ns
+	}
+	var newNodeStatuses []v1alpha1.NodeStatus
+
 	// For each NodePoolSpec in the policy, find matching nodes and ensure counts
 	for _, np := range policy.Spec.LauncherPoolForNodeType {
 		nodePtrs := make([]*corev1.Node, 0, len(nodes.Items))
@@ -72,16 +193,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			continue
 		}
 
+		// LauncherConfig is expected to live in the same namespace as the policy.
+		effectiveNS := policy.Namespace
+
+		launconfigs := make(map[string]*v1alpha1.LauncherConfig, len(np.CountForLauncher))
 		for _, tmplCount := range np.CountForLauncher {
 			tmplName := tmplCount.LauncherConfigName
 			if tmplName == "" {
 				logger.V(3).Info("Skipping template with empty LauncherConfigName", "policy", policy.Name)
 				continue
 			}
-
-			// LauncherConfig is expected to live in the same namespace as the policy.
-			effectiveNS := policy.Namespace
-
+			if _, ok := launconfigs[tmplName]; ok {
+				continue
+			}
 			var launconfig v1alpha1.LauncherConfig
 			if err := r.Get(ctx, apitypes.NamespacedName{Namespace: effectiveNS, Name: tmplName}, &launconfig); err != nil {
 				logger.Error(err, "failed to get LauncherConfig", "namespace", effectiveNS, "name", tmplName)
@@ -89,23 +213,101 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 				// continue to next launcherConfig; if transient error, reconcile will be requeued by the manager
 				continue
 			}
+			launconfigs[tmplName] = &launconfig
+		}
+
+		// Resolve each template's Strategy against matchingNodePtrs, then
+		// clip per-node totals to the node's launcher budget (if any),
+		// trimming lowest-Priority templates first.
+		desiredByNode, desiredCountErrors := computeDesiredCounts(&policy, np, matchingNodePtrs, logger)
+		reconcileErrors = append(reconcileErrors, desiredCountErrors...)
 
-			// Compute desired launcher pods
-			desired := int(tmplCount.LauncherCount)
+		// Visit templates lowest-Priority first, so idle launchers trimmed
+		// from lower-priority templates are deleted before higher-priority
+		// templates create theirs on the same node.
+		byPriority := append([]v1alpha1.CountForLauncher(nil), np.CountForLauncher...)
+		sort.SliceStable(byPriority, func(i, j int) bool { return byPriority[i].Priority < byPriority[j].Priority })
+		for _, tmplCount := range byPriority {
+			tmplName := tmplCount.LauncherConfigName
+			if tmplName == "" {
+				continue
+			}
+			launconfig, resolved := launconfigs[tmplName]
+			if !resolved && !uninstalling {
+				// Without the LauncherConfig we can't build a pod spec, so
+				// there's nothing more to do for this template outside of
+				// uninstall (where we only need to delete, by annotation).
+				continue
+			}
 
 			for _, nodePtr := range matchingNodePtrs {
-				curr, want, err := r.ensureLauncherCount(ctx, &policy, &launconfig, nodePtr, desired, logger)
+				// While uninstalling every template is driven to zero so
+				// all idle launchers are deleted, even one whose
+				// LauncherConfig has already been deleted (e.g. a chart
+				// uninstall removing LauncherConfig and LauncherPoolPolicy
+				// together with no ordering guarantee) — otherwise its pods
+				// would never be drained and the cleanup finalizer would
+				// never come off.
+				desired := desiredByNode[nodePtr.Name][tmplName]
+				if uninstalling {
+					desired = 0
+				}
+
+				result, err := r.ensureLauncherCount(ctx, &policy, tmplName, launconfig, nodePtr, desired, logger)
 				if err != nil {
 					logger.Error(err, "failed to ensure launcher count", "node", nodePtr.Name, "template", tmplName)
 					reconcileErrors = append(reconcileErrors, fmt.Sprintf("node %s template %s: %v", nodePtr.Name, tmplName, err))
 				}
-				logger.V(2).Info("Node/LauncherConfig counts", "node", nodePtr.Name, "launcherConfig", launconfig.Name, "observed", curr, "desired", want)
+				if result.throttledCreates > 0 {
+					throttledCreates += result.throttledCreates
+				}
+				if result.throttledDeletes > 0 {
+					throttledDeletes += result.throttledDeletes
+				}
+				if result.requeueAfter > 0 && (requeueAfter == 0 || result.requeueAfter < requeueAfter) {
+					requeueAfter = result.requeueAfter
+				}
+				logger.V(2).Info("Node/LauncherConfig counts", "node", nodePtr.Name, "launcherConfig", tmplName, "observed", result.curr, "desired", result.desired)
+
+				poolmetrics.Desired.WithLabelValues(policy.Name, tmplName, nodePtr.Name).Set(float64(result.desired))
+				poolmetrics.Observed.WithLabelValues(policy.Name, tmplName, nodePtr.Name).Set(float64(result.curr))
+
+				key := nodePtr.Name + "/" + tmplName
+				lastTransition := metav1.Now()
+				if old, ok := oldNodeStatusByKey[key]; ok && old.Desired == int32(result.desired) && old.Observed == int32(result.curr) {
+					lastTransition = old.LastTransitionTime
+				}
+				newNodeStatuses = append(newNodeStatuses, v1alpha1.NodeStatus{
+					Node:               nodePtr.Name,
+					LauncherConfig:     tmplName,
+					Desired:            int32(result.desired),
+					Observed:           int32(result.curr),
+					LastTransitionTime: lastTransition,
+				})
 			}
 		}
 	}
+	// matchingNodePtrs (and therefore newNodeStatuses) is built by iterating
+	// the controller-runtime cache's node list, whose order isn't stable
+	// across reconciles. Sort by (Node, LauncherConfig) so equalNodeStatuses'
+	// positional comparison doesn't see spurious reordering as a change.
+	sort.Slice(newNodeStatuses, func(i, j int) bool {
+		if newNodeStatuses[i].Node != newNodeStatuses[j].Node {
+			return newNodeStatuses[i].Node < newNodeStatuses[j].Node
+		}
+		return newNodeStatuses[i].LauncherConfig < newNodeStatuses[j].LauncherConfig
+	})
+
 	newStatus := policy.Status
 	newStatus.ObservedGeneration = int32(policy.Generation)
 	newStatus.Errors = reconcileErrors
+	newStatus.ThrottledCreates += throttledCreates
+	newStatus.ThrottledDeletes += throttledDeletes
+	newStatus.NodeStatuses = newNodeStatuses
+
+	if len(reconcileErrors) > 0 {
+		poolmetrics.ReconcileErrorsTotal.WithLabelValues(policy.Name).Inc()
+	}
 
 	if !equalStatus(&policy.Status, &newStatus) {
 		policy.Status = newStatus
@@ -115,10 +317,53 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
+	if uninstalling {
+		remaining, err := r.countLauncherPods(ctx, &policy)
+		if err != nil {
+			logger.Error(err, "failed to count launcher pods during uninstall", "name", policy.Name)
+			return ctrl.Result{}, err
+		}
+		if remaining > 0 {
+			logger.V(2).Info("Uninstall draining, launchers still present", "name", policy.Name, "remaining", remaining)
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		if controllerutil.RemoveFinalizer(&policy, pkgapi.CleanupFinalizerName) {
+			if err := r.Update(ctx, &policy); err != nil {
+				logger.Error(err, "failed to remove cleanup finalizer", "name", policy.Name)
+				return ctrl.Result{}, err
+			}
+			poolmetrics.DeletePolicy(policy.Name)
+			logger.V(2).Info("Uninstall drained, cleanup finalizer removed", "name", policy.Name)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if requeueAfter > 0 {
+		logger.V(2).Info("Reconcile throttled by rate limiter", "policy", policy.Name, "requeueAfter", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	logger.V(2).Info("Reconcile complete", "policy", policy.Name)
 	return ctrl.Result{}, nil
 }
 
+// countLauncherPods counts every pod in the policy's namespace that belongs
+// to it, including ones still terminating, so draining only completes once
+// they have actually disappeared from the API.
+func (r *Reconciler) countLauncherPods(ctx context.Context, policy *v1alpha1.LauncherPoolPolicy) (int, error) {
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(policy.Namespace), client.MatchingLabels{pkgapi.LauncherBasedLabelName: "true"}); err != nil {
+		return 0, err
+	}
+	count := 0
+	for i := range podList.Items {
+		if podList.Items[i].Annotations[pkgapi.PolicyNameAnnotationName] == policy.Name {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // equalStatus helps to compare the relevant status fields.
 func equalStatus(a, b *v1alpha1.LauncherPoolPolicyStatus) bool {
 	if a.ObservedGeneration != b.ObservedGeneration {
@@ -132,16 +377,51 @@ func equalStatus(a, b *v1alpha1.LauncherPoolPolicyStatus) bool {
 			return false
 		}
 	}
+	if a.ThrottledCreates != b.ThrottledCreates || a.ThrottledDeletes != b.ThrottledDeletes {
+		return false
+	}
+	return equalNodeStatuses(a.NodeStatuses, b.NodeStatuses)
+}
+
+func equalNodeStatuses(a, b []v1alpha1.NodeStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Node != b[i].Node || a[i].LauncherConfig != b[i].LauncherConfig ||
+			a[i].Desired != b[i].Desired || a[i].Observed != b[i].Observed ||
+			!a[i].LastTransitionTime.Equal(&b[i].LastTransitionTime) {
+			return false
+		}
+	}
 	return true
 }
 
-// ensureLauncherCount ensures that exactly desired launcher pods exist on the given node for the given template.
-func (r *Reconciler) ensureLauncherCount(ctx context.Context, policy *v1alpha1.LauncherPoolPolicy, launconfig *v1alpha1.LauncherConfig, node *corev1.Node, desired int, logger klog.Logger) (int, int, error) {
-	// List pods in policy.Namespace and filter by annotations for this policy & template
+// ensureLauncherCountResult reports what ensureLauncherCount observed and
+// did, including any rate-limit throttling the caller should fold into the
+// policy's status and reconcile requeue.
+type ensureLauncherCountResult struct {
+	curr, desired                      int
+	throttledCreates, throttledDeletes int64
+	requeueAfter                       time.Duration
+}
+
+// ensureLauncherCount ensures that exactly desired launcher pods exist on
+// the given node for the template named tmplName. launconfig is nil when
+// the LauncherConfig couldn't be resolved (e.g. it was already deleted
+// during an uninstall); that's only safe when desired is 0, since creating
+// a pod needs the config's PodTemplate.
+func (r *Reconciler) ensureLauncherCount(ctx context.Context, policy *v1alpha1.LauncherPoolPolicy, tmplName string, launconfig *v1alpha1.LauncherConfig, node *corev1.Node, desired int, logger klog.Logger) (ensureLauncherCountResult, error) {
+	qps, burst := creationRateOrDefault(policy.Spec.CreationRate)
+
+	// List pods in policy.Namespace and filter by annotations for this policy & template.
+	// The manager's cache is scoped to LauncherBasedLabelName (annotation
+	// selectors aren't supported), so this is now a label-indexed lookup
+	// instead of a full-namespace scan.
 	var podList corev1.PodList
 	effectiveNS := policy.Namespace
-	if err := r.List(ctx, &podList, client.InNamespace(effectiveNS)); err != nil {
-		return 0, 0, err
+	if err := r.List(ctx, &podList, client.InNamespace(effectiveNS), client.MatchingLabels{pkgapi.LauncherBasedLabelName: "true"}); err != nil {
+		return ensureLauncherCountResult{}, err
 	}
 
 	var existing []*corev1.Pod
@@ -161,7 +441,7 @@ func (r *Reconciler) ensureLauncherCount(ctx context.Context, policy *v1alpha1.L
 		if ann[pkgapi.PolicyNameAnnotationName] != policy.Name {
 			continue
 		}
-		if ann[pkgapi.LauncherConfigAnnotationName] != launconfig.Name {
+		if ann[pkgapi.LauncherConfigAnnotationName] != tmplName {
 			continue
 		}
 		if ann[pkgapi.IdleLauncherAnnotationName] != "true" {
@@ -171,32 +451,286 @@ func (r *Reconciler) ensureLauncherCount(ctx context.Context, policy *v1alpha1.L
 	}
 
 	curr := len(existing)
-	logger.V(3).Info("Launcher count", "policy", policy.Name, "template", launconfig.Name, "node", node.Name, "current", curr, "desired", desired)
+	logger.V(3).Info("Launcher count", "policy", policy.Name, "template", tmplName, "node", node.Name, "current", curr, "desired", desired)
+
+	result := ensureLauncherCountResult{curr: curr, desired: desired}
 
 	if curr < desired {
+		if launconfig == nil {
+			return result, fmt.Errorf("cannot create launcher pods for template %q on node %q: LauncherConfig not resolved", tmplName, node.Name)
+		}
 		toCreate := desired - curr
 		for i := 0; i < toCreate; i++ {
+			ok, delay := r.reserveToken(node.Name, qps, burst)
+			if !ok {
+				logger.V(2).Info("Throttled launcher pod creation", "policy", policy.Name, "template", tmplName, "node", node.Name, "delay", delay)
+				poolmetrics.CreateTotal.WithLabelValues(policy.Name, tmplName, node.Name, "throttled").Inc()
+				result.throttledCreates++
+				result.requeueAfter = delay
+				break
+			}
 			if err := r.createLauncherPod(ctx, policy, launconfig, node, logger); err != nil {
-				logger.Error(err, "failed to create launcher pod", "policy", policy.Name, "template", launconfig.Name, "node", node.Name)
+				logger.Error(err, "failed to create launcher pod", "policy", policy.Name, "template", tmplName, "node", node.Name)
+				poolmetrics.CreateTotal.WithLabelValues(policy.Name, tmplName, node.Name, "error").Inc()
 				// continue attempting remaining creations
+				continue
 			}
+			poolmetrics.CreateTotal.WithLabelValues(policy.Name, tmplName, node.Name, "success").Inc()
+			result.curr++
 		}
 	} else if curr > desired {
-		// delete excess pods (delete newest first)
+		// delete excess pods, in the order chosen by SelectForDeletion
+		// (newest-first by default)
 		toDelete := curr - desired
-		sort.Slice(existing, func(i, j int) bool {
-			return existing[i].CreationTimestamp.After(existing[j].CreationTimestamp.Time)
-		})
-		for i := 0; i < toDelete && i < len(existing); i++ {
-			p := existing[i]
+		selectFn := r.SelectForDeletion
+		if selectFn == nil {
+			selectFn = newestFirstSelectForDeletion
+		}
+		toDeletePods := selectFn(existing, toDelete)
+		for _, p := range toDeletePods {
+			ok, delay := r.reserveToken(node.Name, qps, burst)
+			if !ok {
+				logger.V(2).Info("Throttled launcher pod deletion", "policy", policy.Name, "template", tmplName, "node", node.Name, "delay", delay)
+				poolmetrics.DeleteTotal.WithLabelValues(policy.Name, tmplName, node.Name, "throttled").Inc()
+				result.throttledDeletes++
+				result.requeueAfter = delay
+				break
+			}
 			if err := r.Delete(ctx, p); err != nil && !apierrors.IsNotFound(err) {
 				logger.Error(err, "failed to delete excess launcher pod", "pod", p.Name)
-			} else {
-				logger.V(2).Info("Deleted excess launcher pod", "pod", p.Name)
+				poolmetrics.DeleteTotal.WithLabelValues(policy.Name, tmplName, node.Name, "error").Inc()
+				continue
+			}
+			poolmetrics.DeleteTotal.WithLabelValues(policy.Name, tmplName, node.Name, "success").Inc()
+			logger.V(2).Info("Deleted excess launcher pod", "pod", p.Name)
+			result.curr--
+		}
+	}
+	return result, nil
+}
+
+// newestFirstSelectForDeletion is the default SelectForDeletion: it deletes
+// the most recently created idle launchers first, on the theory that an
+// older idle launcher is more likely to have been observed healthy for
+// longer and is cheaper to keep than to recreate.
+func newestFirstSelectForDeletion(pods []*corev1.Pod, n int) []*corev1.Pod {
+	sorted := append([]*corev1.Pod(nil), pods...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimestamp.After(sorted[j].CreationTimestamp.Time)
+	})
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+	return sorted[:n]
+}
+
+// largestRemainderAllocate distributes total units across weights
+// proportionally, using the largest-remainder method so the per-weight
+// shares sum to exactly total instead of drifting from integer truncation.
+// Returns a zero-valued slice of len(weights) if total <= 0 or every weight
+// is non-positive.
+func largestRemainderAllocate(total int, weights []int) []int {
+	alloc := make([]int, len(weights))
+	if total <= 0 || len(weights) == 0 {
+		return alloc
+	}
+	weightSum := 0
+	for _, w := range weights {
+		if w > 0 {
+			weightSum += w
+		}
+	}
+	if weightSum <= 0 {
+		return alloc
+	}
+
+	type remainder struct {
+		index int
+		frac  float64
+	}
+	remainders := make([]remainder, len(weights))
+	assigned := 0
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		share := float64(total) * float64(w) / float64(weightSum)
+		floor := int(share)
+		alloc[i] = floor
+		assigned += floor
+		remainders[i] = remainder{index: i, frac: share - float64(floor)}
+	}
+	sort.Slice(remainders, func(i, j int) bool {
+		return remainders[i].frac > remainders[j].frac
+	})
+	for i := 0; i < total-assigned && i < len(remainders); i++ {
+		alloc[remainders[i].index]++
+	}
+	return alloc
+}
+
+// spreadAcrossTopology allocates target launchers across nodes, first
+// spreading evenly across the distinct values of the topologyKey label
+// (falling back to a single domain for nodes missing the label), then
+// spreading each domain's share evenly across its nodes.
+func spreadAcrossTopology(nodes []*corev1.Node, topologyKey string, target int) map[string]int {
+	perNode := make(map[string]int, len(nodes))
+
+	domainNodes := make(map[string][]*corev1.Node)
+	for _, n := range nodes {
+		domain := n.Labels[topologyKey]
+		domainNodes[domain] = append(domainNodes[domain], n)
+	}
+
+	domains := make([]string, 0, len(domainNodes))
+	for d := range domainNodes {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+
+	domainWeights := make([]int, len(domains))
+	for i := range domains {
+		domainWeights[i] = 1
+	}
+	domainAlloc := largestRemainderAllocate(target, domainWeights)
+
+	for i, domain := range domains {
+		nodesInDomain := domainNodes[domain]
+		sort.Slice(nodesInDomain, func(i, j int) bool { return nodesInDomain[i].Name < nodesInDomain[j].Name })
+		nodeWeights := make([]int, len(nodesInDomain))
+		for j := range nodesInDomain {
+			nodeWeights[j] = 1
+		}
+		nodeAlloc := largestRemainderAllocate(domainAlloc[i], nodeWeights)
+		for j, n := range nodesInDomain {
+			perNode[n.Name] = nodeAlloc[j]
+		}
+	}
+	return perNode
+}
+
+// resolveNodeBudget returns the cap on total launcher pods (summed across
+// every CountForLauncher template) this policy may place on node. A node's
+// MaxLaunchersPerNodeAnnotationName annotation takes precedence over
+// Spec.MaxLaunchersPerNode. ok is false if neither is set, meaning no cap.
+func resolveNodeBudget(policy *v1alpha1.LauncherPoolPolicy, node *corev1.Node) (budget int, ok bool) {
+	if v, present := node.Annotations[pkgapi.MaxLaunchersPerNodeAnnotationName]; present {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n, true
+		}
+	}
+	if policy.Spec.MaxLaunchersPerNode != nil {
+		return int(*policy.Spec.MaxLaunchersPerNode), true
+	}
+	return 0, false
+}
+
+// clipToNodeBudget reduces perTemplate (keyed by LauncherConfigName) in
+// place so its values sum to at most budget, when ok is true. Lower-Priority
+// templates are trimmed to zero first; ties and any remaining overage are
+// resolved in templates order.
+func clipToNodeBudget(perTemplate map[string]int, templates []v1alpha1.CountForLauncher, budget int, ok bool) {
+	if !ok {
+		return
+	}
+	total := 0
+	for _, t := range templates {
+		total += perTemplate[t.LauncherConfigName]
+	}
+	if total <= budget {
+		return
+	}
+
+	ordered := append([]v1alpha1.CountForLauncher(nil), templates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	overage := total - budget
+	for _, t := range ordered {
+		if overage <= 0 {
+			break
+		}
+		name := t.LauncherConfigName
+		trim := perTemplate[name]
+		if trim > overage {
+			trim = overage
+		}
+		perTemplate[name] -= trim
+		overage -= trim
+	}
+}
+
+// computeDesiredCounts resolves np.CountForLauncher's Strategy against
+// matchingNodePtrs into desired[nodeName][launcherConfigName], then clips
+// each node's total to its launcher budget (resolveNodeBudget), trimming
+// lowest-Priority templates first. Returns any problems it hit (e.g. a
+// Weighted template with no resolvable node budget) as strings suitable for
+// the caller to fold into Status.Errors.
+func computeDesiredCounts(policy *v1alpha1.LauncherPoolPolicy, np v1alpha1.NodePoolSpec, matchingNodePtrs []*corev1.Node, logger klog.Logger) (map[string]map[string]int, []string) {
+	desired := make(map[string]map[string]int, len(matchingNodePtrs))
+	for _, n := range matchingNodePtrs {
+		desired[n.Name] = make(map[string]int, len(np.CountForLauncher))
+	}
+
+	var errs []string
+	var weightedTemplates []v1alpha1.CountForLauncher
+	for _, tmplCount := range np.CountForLauncher {
+		name := tmplCount.LauncherConfigName
+		if name == "" {
+			continue
+		}
+		switch {
+		case tmplCount.Strategy.Fixed != nil:
+			for _, n := range matchingNodePtrs {
+				desired[n.Name][name] = int(tmplCount.Strategy.Fixed.Count)
+			}
+		case tmplCount.Strategy.TopologySpread != nil:
+			perNode := spreadAcrossTopology(matchingNodePtrs, tmplCount.Strategy.TopologySpread.TopologyKey, int(tmplCount.Strategy.TopologySpread.Target))
+			for _, n := range matchingNodePtrs {
+				desired[n.Name][name] = perNode[n.Name]
 			}
+		case tmplCount.Strategy.Weighted != nil:
+			weightedTemplates = append(weightedTemplates, tmplCount)
 		}
 	}
-	return curr, desired, nil
+
+	if len(weightedTemplates) > 0 {
+		weights := make([]int, len(weightedTemplates))
+		for i, t := range weightedTemplates {
+			weights[i] = int(t.Strategy.Weighted.Weight)
+		}
+		for _, n := range matchingNodePtrs {
+			budget, ok := resolveNodeBudget(policy, n)
+			if !ok {
+				logger.V(2).Info("Weighted template has no resolvable node budget, desired count stays 0", "node", n.Name)
+				errs = append(errs, fmt.Sprintf("node %s: weighted templates need spec.maxLaunchersPerNode or the node's %s annotation to resolve a budget; desired count left at 0", n.Name, pkgapi.MaxLaunchersPerNodeAnnotationName))
+				continue
+			}
+			alloc := largestRemainderAllocate(budget, weights)
+			for i, t := range weightedTemplates {
+				desired[n.Name][t.LauncherConfigName] = alloc[i]
+			}
+		}
+	}
+
+	for _, n := range matchingNodePtrs {
+		budget, ok := resolveNodeBudget(policy, n)
+		clipToNodeBudget(desired[n.Name], np.CountForLauncher, budget, ok)
+	}
+	return desired, errs
+}
+
+// creationRateOrDefault returns the configured qps/burst for a policy, or
+// the controller's defaults if the policy doesn't set Spec.CreationRate.
+func creationRateOrDefault(cr *v1alpha1.CreationRate) (qps float64, burst int) {
+	if cr == nil || cr.QPS <= 0 {
+		return defaultNodeRateQPS, defaultNodeRateBurst
+	}
+	if cr.Burst <= 0 {
+		return cr.QPS, defaultNodeRateBurst
+	}
+	return cr.QPS, cr.Burst
 }
 
 // createLauncherPod instantiates a Pod using the LauncherConfig's PodTemplate,
@@ -206,7 +740,9 @@ func (r *Reconciler) createLauncherPod(ctx context.Context, policy *v1alpha1.Lau
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: policy.Name + "-" + launconfig.Name + "-" + node.Name + "-",
 			Namespace:    policy.Namespace,
-			Labels:       map[string]string{},
+			Labels: map[string]string{
+				pkgapi.LauncherBasedLabelName: "true",
+			},
 			Annotations: map[string]string{
 				pkgapi.PolicyNameAnnotationName:     policy.Name,
 				pkgapi.LauncherConfigAnnotationName: launconfig.Name,
@@ -214,12 +750,22 @@ func (r *Reconciler) createLauncherPod(ctx context.Context, policy *v1alpha1.Lau
 				pkgapi.LauncherBasedAnnotationName:  "true",
 			},
 		},
-		Spec: launconfig.Spec.PodTemplate.Spec,
 	}
+	// Deep-copy the template spec before mutating it below: a shallow
+	// assignment would alias launconfig's Containers/Volumes/Env slices and
+	// the overlay merge below would write through into launconfig in place.
+	pod.Spec = *launconfig.Spec.PodTemplate.Spec.DeepCopy()
 
 	// Force schedule to specific node
 	pod.Spec.NodeName = node.Name
 
+	// Merge in any LauncherConfig overlay (extra env, volumes, mounts, pull
+	// secrets, node selector) before the image-override logic below, so an
+	// overlay-provided ImageFromEnv can still take precedence afterwards.
+	if launconfig.Spec.Overlay != nil {
+		applyOverlayResources(pod, launconfig.Spec.Overlay)
+	}
+
 	// Ensure launcher image is set from env var LAUNCHER_IMAGE or constructed from
 	// CONTAINER_IMG_REG / LAUNCHER_IMG_REPO / LAUNCHER_IMG_TAG. If none available,
 	// leave whatever image is already present in the pod template.
@@ -248,6 +794,13 @@ func (r *Reconciler) createLauncherPod(ctx context.Context, policy *v1alpha1.Lau
 		pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
 	}
 
+	// Apply the overlay's ImageFromEnv override last so it isn't clobbered
+	// by the LAUNCHER_IMAGE pass above, which otherwise sets every
+	// container's image unconditionally.
+	if launconfig.Spec.Overlay != nil {
+		applyOverlayImageFromEnv(pod, launconfig.Spec.Overlay, logger)
+	}
+
 	// Set owner reference to the policy so pods are garbage-collected if the policy is removed
 	if err := controllerutil.SetOwnerReference(policy, pod, r.Scheme); err != nil {
 		logger.Error(err, "failed to set owner reference on pod")
@@ -256,6 +809,68 @@ func (r *Reconciler) createLauncherPod(ctx context.Context, policy *v1alpha1.Lau
 	return r.Create(ctx, pod)
 }
 
+// applyOverlayResources merges the non-image fields of a LauncherConfig
+// Overlay onto pod: env vars are appended per-container with overlay
+// winning on name collision, volumes/mounts/pull secrets are appended, and
+// NodeSelectorOverlay is merged in, overriding any matching key already set
+// by the PodTemplate.
+func applyOverlayResources(pod *corev1.Pod, overlay *v1alpha1.Overlay) {
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = mergeEnvVars(pod.Spec.Containers[i].Env, overlay.ExtraEnv)
+		pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, overlay.ExtraVolumeMounts...)
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, overlay.ExtraVolumes...)
+	pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, overlay.ImagePullSecrets...)
+
+	if len(overlay.NodeSelectorOverlay) > 0 {
+		if pod.Spec.NodeSelector == nil {
+			pod.Spec.NodeSelector = map[string]string{}
+		}
+		for k, v := range overlay.NodeSelectorOverlay {
+			pod.Spec.NodeSelector[k] = v
+		}
+	}
+}
+
+// mergeEnvVars appends overlay onto base, with an overlay entry overriding
+// the value of any base entry sharing its Name instead of duplicating it.
+func mergeEnvVars(base, overlay []corev1.EnvVar) []corev1.EnvVar {
+	indexByName := make(map[string]int, len(base))
+	for i, e := range base {
+		indexByName[e.Name] = i
+	}
+	for _, e := range overlay {
+		if i, ok := indexByName[e.Name]; ok {
+			base[i] = e
+			continue
+		}
+		base = append(base, e)
+		indexByName[e.Name] = len(base) - 1
+	}
+	return base
+}
+
+// applyOverlayImageFromEnv overrides the image of ImageFromEnvContainer (or
+// every container if unset) from the environment variable named by
+// ImageFromEnv, mirroring the LAUNCHER_IMAGE override but scoped to a
+// single container so a sidecar's image can be parameterized independently.
+func applyOverlayImageFromEnv(pod *corev1.Pod, overlay *v1alpha1.Overlay, logger klog.Logger) {
+	if overlay.ImageFromEnv == "" {
+		return
+	}
+	image := os.Getenv(overlay.ImageFromEnv)
+	if image == "" {
+		return
+	}
+	for i := range pod.Spec.Containers {
+		if overlay.ImageFromEnvContainer != "" && pod.Spec.Containers[i].Name != overlay.ImageFromEnvContainer {
+			continue
+		}
+		pod.Spec.Containers[i].Image = image
+	}
+	logger.V(3).Info("Set overlay container image from env", "env", overlay.ImageFromEnv, "container", overlay.ImageFromEnvContainer)
+}
+
 // SetupWithManager registers this controller with the provided manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if r.Scheme != nil {
@@ -265,5 +880,141 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.LauncherPoolPolicy{}).
+		Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.policiesForNode),
+			ctrlbuilder.WithPredicates(nodeSelectionRelevantPredicate()),
+		).
+		Watches(
+			&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(r.policiesForPod),
+			ctrlbuilder.WithPredicates(launcherPodTransitionPredicate()),
+		).
 		Complete(r)
 }
+
+// policiesForNode maps a Node event to reconcile requests for every
+// LauncherPoolPolicy whose EnhancedNodeSelector matches that node. A new
+// node coming up (or an existing one changing readiness/taints) can change
+// which policies are under- or over-provisioned, so this lets scale-up and
+// cordon/drain handling happen on the node event itself instead of waiting
+// for the next resync.
+func (r *Reconciler) policiesForNode(ctx context.Context, obj client.Object) []reconcile.Request {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return nil
+	}
+
+	var policies v1alpha1.LauncherPoolPolicyList
+	if err := r.List(ctx, &policies); err != nil {
+		klog.FromContext(ctx).Error(err, "failed to list LauncherPoolPolicy for node mapping", "node", node.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		for _, np := range policy.Spec.LauncherPoolForNodeType {
+			matched, err := nodeutil.Matches(node, &np.EnhancedNodeSelector)
+			if err != nil {
+				continue
+			}
+			if matched {
+				requests = append(requests, reconcile.Request{NamespacedName: apitypes.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// policiesForPod maps a launcher Pod event to a reconcile request for the
+// LauncherPoolPolicy recorded in its PolicyNameAnnotationName annotation, so
+// an idle launcher disappearing (e.g. deleted out-of-band) triggers
+// self-healing immediately instead of on the next resync.
+func (r *Reconciler) policiesForPod(_ context.Context, obj client.Object) []reconcile.Request {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	policyName, ok := pod.Annotations[pkgapi.PolicyNameAnnotationName]
+	if !ok || policyName == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: apitypes.NamespacedName{Namespace: pod.Namespace, Name: policyName}}}
+}
+
+// nodeSelectionRelevantPredicate only lets Node updates through when a field
+// that could affect EnhancedNodeSelector matching actually changed, so a
+// heartbeat-only status update doesn't trigger a reconcile storm.
+func nodeSelectionRelevantPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		DeleteFunc: func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok1 := e.ObjectOld.(*corev1.Node)
+			newNode, ok2 := e.ObjectNew.(*corev1.Node)
+			if !ok1 || !ok2 {
+				return false
+			}
+			if !equalLabels(oldNode.Labels, newNode.Labels) {
+				return true
+			}
+			if !equalTaints(oldNode.Spec.Taints, newNode.Spec.Taints) {
+				return true
+			}
+			return nodeReadyCondition(oldNode) != nodeReadyCondition(newNode)
+		},
+	}
+}
+
+// launcherPodTransitionPredicate only reconciles on create/delete of pods
+// carrying PolicyNameAnnotationName: creation means a launcher finished
+// coming up (observed count changed), deletion means one disappeared and
+// needs replacing. In-place status updates don't change the observed count.
+func launcherPodTransitionPredicate() predicate.Predicate {
+	isLauncherPod := func(obj client.Object) bool {
+		_, ok := obj.GetAnnotations()[pkgapi.PolicyNameAnnotationName]
+		return ok
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isLauncherPod(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isLauncherPod(e.Object) },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+func equalLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func equalTaints(a, b []corev1.Taint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || a[i].Value != b[i].Value || a[i].Effect != b[i].Effect {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeReadyCondition(node *corev1.Node) corev1.ConditionStatus {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
@@ -0,0 +1,155 @@
+package poolpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/llm-d-incubation/llm-d-fast-model-actuation/api/v1alpha1"
+)
+
+func TestLargestRemainderAllocate(t *testing.T) {
+	cases := []struct {
+		name    string
+		total   int
+		weights []int
+		want    []int
+	}{
+		{name: "even split", total: 9, weights: []int{1, 1, 1}, want: []int{3, 3, 3}},
+		{name: "remainder goes to largest fraction", total: 10, weights: []int{3, 4, 5}, want: []int{3, 3, 4}},
+		{name: "proportional to weight", total: 10, weights: []int{2, 3}, want: []int{4, 6}},
+		{name: "zero total", total: 0, weights: []int{1, 1}, want: []int{0, 0}},
+		{name: "negative total", total: -5, weights: []int{1, 1}, want: []int{0, 0}},
+		{name: "no weights", total: 5, weights: []int{}, want: []int{}},
+		{name: "all weights non-positive", total: 5, weights: []int{0, -1}, want: []int{0, 0}},
+		{name: "non-positive weight ignored", total: 4, weights: []int{0, 1, -2, 1}, want: []int{0, 2, 0, 2}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := largestRemainderAllocate(c.total, c.weights)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("largestRemainderAllocate(%d, %v) = %v, want %v", c.total, c.weights, got, c.want)
+			}
+			sum := 0
+			for _, v := range got {
+				sum += v
+			}
+			// Nothing is allocated when total is non-positive or no weight
+			// is positive; otherwise the shares sum to exactly total.
+			hasPositiveWeight := false
+			for _, w := range c.weights {
+				if w > 0 {
+					hasPositiveWeight = true
+				}
+			}
+			wantSum := c.total
+			if wantSum < 0 || !hasPositiveWeight {
+				wantSum = 0
+			}
+			if sum != wantSum {
+				t.Errorf("largestRemainderAllocate(%d, %v) sums to %d, want %d", c.total, c.weights, sum, wantSum)
+			}
+		})
+	}
+}
+
+func nodeWithLabel(name, key, value string) *corev1.Node {
+	n := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if key != "" {
+		n.Labels = map[string]string{key: value}
+	}
+	return n
+}
+
+func TestSpreadAcrossTopology(t *testing.T) {
+	t.Run("spreads evenly across zones then nodes", func(t *testing.T) {
+		nodes := []*corev1.Node{
+			nodeWithLabel("a1", "zone", "a"),
+			nodeWithLabel("a2", "zone", "a"),
+			nodeWithLabel("b1", "zone", "b"),
+		}
+		got := spreadAcrossTopology(nodes, "zone", 4)
+		want := map[string]int{"a1": 1, "a2": 1, "b1": 2}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("spreadAcrossTopology = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("nodes missing the label fall back to one domain", func(t *testing.T) {
+		nodes := []*corev1.Node{
+			nodeWithLabel("n1", "", ""),
+			nodeWithLabel("n2", "", ""),
+		}
+		got := spreadAcrossTopology(nodes, "zone", 3)
+		sum := 0
+		for _, v := range got {
+			sum += v
+		}
+		if sum != 3 {
+			t.Errorf("spreadAcrossTopology total = %d, want 3", sum)
+		}
+	})
+
+	t.Run("zero target allocates nothing", func(t *testing.T) {
+		nodes := []*corev1.Node{nodeWithLabel("a1", "zone", "a")}
+		got := spreadAcrossTopology(nodes, "zone", 0)
+		want := map[string]int{"a1": 0}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("spreadAcrossTopology = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestClipToNodeBudget(t *testing.T) {
+	templates := []v1alpha1.CountForLauncher{
+		{LauncherConfigName: "low", Priority: 0},
+		{LauncherConfigName: "high", Priority: 10},
+	}
+
+	t.Run("no budget set is a no-op", func(t *testing.T) {
+		perTemplate := map[string]int{"low": 5, "high": 5}
+		clipToNodeBudget(perTemplate, templates, 0, false)
+		want := map[string]int{"low": 5, "high": 5}
+		if !reflect.DeepEqual(perTemplate, want) {
+			t.Errorf("perTemplate = %v, want %v", perTemplate, want)
+		}
+	})
+
+	t.Run("under budget is a no-op", func(t *testing.T) {
+		perTemplate := map[string]int{"low": 2, "high": 2}
+		clipToNodeBudget(perTemplate, templates, 10, true)
+		want := map[string]int{"low": 2, "high": 2}
+		if !reflect.DeepEqual(perTemplate, want) {
+			t.Errorf("perTemplate = %v, want %v", perTemplate, want)
+		}
+	})
+
+	t.Run("trims lowest priority first", func(t *testing.T) {
+		perTemplate := map[string]int{"low": 5, "high": 5}
+		clipToNodeBudget(perTemplate, templates, 6, true)
+		want := map[string]int{"low": 1, "high": 5}
+		if !reflect.DeepEqual(perTemplate, want) {
+			t.Errorf("perTemplate = %v, want %v", perTemplate, want)
+		}
+	})
+
+	t.Run("budget of zero trims everything", func(t *testing.T) {
+		perTemplate := map[string]int{"low": 3, "high": 3}
+		clipToNodeBudget(perTemplate, templates, 0, true)
+		want := map[string]int{"low": 0, "high": 0}
+		if !reflect.DeepEqual(perTemplate, want) {
+			t.Errorf("perTemplate = %v, want %v", perTemplate, want)
+		}
+	})
+
+	t.Run("overage beyond lowest priority spills into the next template", func(t *testing.T) {
+		perTemplate := map[string]int{"low": 2, "high": 5}
+		clipToNodeBudget(perTemplate, templates, 3, true)
+		want := map[string]int{"low": 0, "high": 3}
+		if !reflect.DeepEqual(perTemplate, want) {
+			t.Errorf("perTemplate = %v, want %v", perTemplate, want)
+		}
+	})
+}
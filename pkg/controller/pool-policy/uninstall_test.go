@@ -0,0 +1,101 @@
+package poolpolicy
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	v1alpha1 "github.com/llm-d-incubation/llm-d-fast-model-actuation/api/v1alpha1"
+	pkgapi "github.com/llm-d-incubation/llm-d-fast-model-actuation/pkg/api"
+)
+
+// TestReconcileDrainsPodsOfMissingLauncherConfigDuringUninstall covers the
+// case where a chart uninstall deletes a LauncherConfig before (or without)
+// deleting the LauncherPoolPolicy that references it: the idle launcher pods
+// created from that template must still be drained, and the cleanup
+// finalizer must still come off, even though the LauncherConfig itself can
+// no longer be fetched.
+func TestReconcileDrainsPodsOfMissingLauncherConfigDuringUninstall(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add corev1 to scheme: %v", err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add v1alpha1 to scheme: %v", err)
+	}
+
+	policy := &v1alpha1.LauncherPoolPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "pool",
+			Namespace:  "default",
+			Finalizers: []string{pkgapi.CleanupFinalizerName},
+			Annotations: map[string]string{
+				pkgapi.UninstallingAnnotationName: "true",
+			},
+		},
+		Spec: v1alpha1.LauncherPoolPolicySpec{
+			LauncherPoolForNodeType: []v1alpha1.NodePoolSpec{
+				{
+					CountForLauncher: []v1alpha1.CountForLauncher{
+						{
+							LauncherConfigName: "missing-config",
+							Strategy:           v1alpha1.LauncherCountStrategy{Fixed: &v1alpha1.FixedStrategy{Count: 1}},
+						},
+					},
+				},
+			},
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "launcher-1",
+			Namespace: "default",
+			Labels: map[string]string{
+				pkgapi.LauncherBasedLabelName: "true",
+			},
+			Annotations: map[string]string{
+				pkgapi.PolicyNameAnnotationName:     "pool",
+				pkgapi.LauncherConfigAnnotationName: "missing-config",
+				pkgapi.IdleLauncherAnnotationName:   "true",
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1alpha1.LauncherPoolPolicy{}).
+		WithObjects(policy, node, pod).
+		Build()
+
+	r := &Reconciler{Client: c, Scheme: scheme, Logger: klog.Background()}
+
+	ctx := context.Background()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: apitypes.NamespacedName{Namespace: "default", Name: "pool"}}); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	var gotPod corev1.Pod
+	err := c.Get(ctx, apitypes.NamespacedName{Namespace: "default", Name: "launcher-1"}, &gotPod)
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected launcher pod for the missing LauncherConfig to be deleted, got err=%v", err)
+	}
+
+	var gotPolicy v1alpha1.LauncherPoolPolicy
+	if err := c.Get(ctx, apitypes.NamespacedName{Namespace: "default", Name: "pool"}, &gotPolicy); err != nil {
+		t.Fatalf("failed to get policy: %v", err)
+	}
+	if controllerutil.ContainsFinalizer(&gotPolicy, pkgapi.CleanupFinalizerName) {
+		t.Fatalf("expected cleanup finalizer to be removed once the policy's pods are drained")
+	}
+}
@@ -0,0 +1,68 @@
+// Package metrics registers the Prometheus series the pool-policy
+// controller exposes, so launcher pool rollout is observable via a
+// standard Prometheus scrape in addition to `kubectl get
+// launcherpoolpolicy -o yaml`.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// Desired reports the desired launcher pod count per policy, launcher
+	// config, and node, as last computed by Reconcile.
+	Desired = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "launcherpool_desired",
+		Help: "Desired launcher pod count per policy, launcher config, and node.",
+	}, []string{"policy", "launcher_config", "node"})
+
+	// Observed reports the currently observed launcher pod count per policy,
+	// launcher config, and node.
+	Observed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "launcherpool_observed",
+		Help: "Observed launcher pod count per policy, launcher config, and node.",
+	}, []string{"policy", "launcher_config", "node"})
+
+	// CreateTotal counts launcher pod create attempts, labeled by outcome.
+	CreateTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "launcherpool_create_total",
+		Help: "Launcher pod create attempts per policy, launcher config, node, and result.",
+	}, []string{"policy", "launcher_config", "node", "result"})
+
+	// DeleteTotal counts launcher pod delete attempts, labeled by outcome.
+	DeleteTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "launcherpool_delete_total",
+		Help: "Launcher pod delete attempts per policy, launcher config, node, and result.",
+	}, []string{"policy", "launcher_config", "node", "result"})
+
+	// ReconcileErrorsTotal counts Reconcile calls that recorded at least one
+	// error in the policy's Status.Errors, per policy.
+	ReconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "launcherpool_reconcile_errors_total",
+		Help: "Reconcile calls that recorded at least one error in the policy's status, per policy.",
+	}, []string{"policy"})
+
+	// ReconcileDuration times a single Reconcile call, per policy.
+	ReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "launcherpool_reconcile_duration_seconds",
+		Help:    "Time spent in a single Reconcile call, per policy.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"policy"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(Desired, Observed, CreateTotal, DeleteTotal, ReconcileErrorsTotal, ReconcileDuration)
+}
+
+// DeletePolicy removes every series this package emitted for policyName, so
+// a deleted LauncherPoolPolicy doesn't leave stale series behind forever.
+func DeletePolicy(policyName string) {
+	match := prometheus.Labels{"policy": policyName}
+	Desired.DeletePartialMatch(match)
+	Observed.DeletePartialMatch(match)
+	CreateTotal.DeletePartialMatch(match)
+	DeleteTotal.DeletePartialMatch(match)
+	ReconcileErrorsTotal.DeletePartialMatch(match)
+	ReconcileDuration.DeletePartialMatch(match)
+}
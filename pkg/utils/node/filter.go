@@ -0,0 +1,76 @@
+// Package node provides helpers for selecting Kubernetes Nodes using a
+// selector richer than the plain label selector accepted by List options.
+package node
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EnhancedNodeSelector matches nodes on labels as well as on properties that
+// a plain label selector cannot express, such as taints and conditions.
+type EnhancedNodeSelector struct {
+	// LabelSelector is evaluated against the node's labels. A nil selector
+	// matches every node.
+	LabelSelector labels.Selector `json:"-"`
+
+	// RequireReady, when true, excludes nodes whose Ready condition is not True.
+	RequireReady bool `json:"requireReady,omitempty"`
+
+	// TolerateTaints lists taints that should not disqualify a node from
+	// selection, mirroring pod toleration semantics. Any taint on the node
+	// that is not tolerated here disqualifies the node.
+	TolerateTaints []corev1.Toleration `json:"tolerateTaints,omitempty"`
+}
+
+// Matches reports whether the given node satisfies sel.
+func Matches(node *corev1.Node, sel *EnhancedNodeSelector) (bool, error) {
+	if sel.LabelSelector != nil && !sel.LabelSelector.Matches(labels.Set(node.Labels)) {
+		return false, nil
+	}
+
+	if sel.RequireReady && !nodeIsReady(node) {
+		return false, nil
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if !taintTolerated(taint, sel.TolerateTaints) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// FilterNodes returns the subset of nodes that match sel.
+func FilterNodes(nodes []*corev1.Node, sel *EnhancedNodeSelector) ([]*corev1.Node, error) {
+	var matched []*corev1.Node
+	for _, n := range nodes {
+		ok, err := Matches(n, sel)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, n)
+		}
+	}
+	return matched, nil
+}
+
+func nodeIsReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func taintTolerated(taint corev1.Taint, tolerations []corev1.Toleration) bool {
+	for _, t := range tolerations {
+		if t.ToleratesTaint(&taint) {
+			return true
+		}
+	}
+	return false
+}